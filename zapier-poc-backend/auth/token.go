@@ -0,0 +1,52 @@
+// Package auth génère et vérifie les JWT utilisés pour authentifier les
+// utilisateurs.
+package auth
+
+import (
+	"errors"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// tokenTTL est la durée de validité d'un token émis par GenerateToken.
+const tokenTTL = 72 * time.Hour
+
+// secretKey retourne la clé de signature HS256, lue depuis JWT_SECRET.
+// À défaut (environnement de développement du POC), une clé de repli est
+// utilisée ; ne jamais s'en servir en production.
+func secretKey() []byte {
+	if secret := os.Getenv("JWT_SECRET"); secret != "" {
+		return []byte(secret)
+	}
+	return []byte("zapier-poc-dev-secret")
+}
+
+// GenerateToken émet un JWT HS256 valide 72h, avec l'ID utilisateur comme
+// sujet ("sub").
+func GenerateToken(userID string) (string, error) {
+	claims := jwt.RegisteredClaims{
+		Subject:   userID,
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(tokenTTL)),
+		IssuedAt:  jwt.NewNumericDate(time.Now()),
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(secretKey())
+}
+
+// ParseUserID vérifie la signature d'un JWT et en extrait l'ID utilisateur
+// ("sub").
+func ParseUserID(tokenString string) (string, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &jwt.RegisteredClaims{}, func(t *jwt.Token) (interface{}, error) {
+		return secretKey(), nil
+	})
+	if err != nil {
+		return "", err
+	}
+	claims, ok := token.Claims.(*jwt.RegisteredClaims)
+	if !ok || !token.Valid {
+		return "", errors.New("auth: invalid token")
+	}
+	return claims.Subject, nil
+}
@@ -0,0 +1,35 @@
+package auth
+
+import "testing"
+
+func TestGenerateAndParseTokenRoundTrip(t *testing.T) {
+	token, err := GenerateToken("user-42")
+	if err != nil {
+		t.Fatalf("GenerateToken: %v", err)
+	}
+
+	userID, err := ParseUserID(token)
+	if err != nil {
+		t.Fatalf("ParseUserID: %v", err)
+	}
+	if userID != "user-42" {
+		t.Fatalf("expected userID %q, got %q", "user-42", userID)
+	}
+}
+
+func TestParseUserIDRejectsGarbage(t *testing.T) {
+	if _, err := ParseUserID("not-a-jwt"); err == nil {
+		t.Fatal("expected an error for a malformed token")
+	}
+}
+
+func TestParseUserIDRejectsTamperedSignature(t *testing.T) {
+	token, err := GenerateToken("user-42")
+	if err != nil {
+		t.Fatalf("GenerateToken: %v", err)
+	}
+
+	if _, err := ParseUserID(token + "tampered"); err == nil {
+		t.Fatal("expected an error for a tampered token")
+	}
+}
@@ -0,0 +1,191 @@
+package engine
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrQueueFull est renvoyée par Submit/SubmitWithContext quand la file de
+// jobs est pleine : l'appelant doit réessayer plus tard plutôt que
+// d'attendre qu'un worker se libère.
+var ErrQueueFull = errors.New("engine: run queue is full")
+
+// StepResult capture le résultat d'exécution d'une étape de workflow.
+type StepResult struct {
+	Name   string `json:"name"`
+	Status string `json:"status"` // "success" ou "failed"
+	Output string `json:"output,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Run suit l'exécution d'un workflow, étape par étape.
+type Run struct {
+	ID         string       `json:"id"`
+	WorkflowID string       `json:"workflow_id"`
+	Status     string       `json:"status"` // "pending", "running", "success" ou "failed"
+	Steps      []StepResult `json:"steps"`
+}
+
+// RunStore persiste l'état des runs de workflow. store.Store la satisfait
+// structurellement, ce qui permet à l'Executor de survivre à un redémarrage
+// sans dépendre du package store (qui importe déjà engine).
+type RunStore interface {
+	CreateRun(run Run) (Run, error)
+	UpdateRunStatus(runID string, status string) error
+	AppendRunStep(runID string, step StepResult) error
+	GetRun(id string) (Run, error)
+}
+
+type job struct {
+	run   Run
+	steps []ActionStep
+}
+
+// Executor exécute les workflows de façon asynchrone via un pool de
+// workers consommant une file de jobs, et persiste l'état des runs dans
+// un RunStore.
+type Executor struct {
+	registry *Registry
+	store    RunStore
+	workers  int
+	queue    chan job
+}
+
+// NewExecutor crée un Executor avec le nombre de workers donné. Start doit
+// être appelé pour démarrer le traitement des jobs.
+func NewExecutor(workers int, registry *Registry, store RunStore) *Executor {
+	return &Executor{
+		registry: registry,
+		store:    store,
+		workers:  workers,
+		queue:    make(chan job, 256),
+	}
+}
+
+// Start lance le pool de workers qui consomment la file de jobs.
+func (e *Executor) Start() {
+	for i := 0; i < e.workers; i++ {
+		go e.worker()
+	}
+}
+
+func (e *Executor) worker() {
+	for j := range e.queue {
+		e.runSteps(j.run, j.steps)
+	}
+}
+
+// Submit crée un run "pending" pour le workflow donné et l'enqueue pour
+// exécution asynchrone. Il retourne immédiatement l'ID du run créé, ou
+// ErrQueueFull sans bloquer si la file est pleine.
+func (e *Executor) Submit(workflowID string, steps []ActionStep) (string, error) {
+	run, err := e.store.CreateRun(Run{ID: NewID(), WorkflowID: workflowID, Status: "pending"})
+	if err != nil {
+		return "", err
+	}
+
+	select {
+	case e.queue <- job{run: run, steps: steps}:
+	default:
+		_ = e.store.UpdateRunStatus(run.ID, "failed")
+		return "", ErrQueueFull
+	}
+	return run.ID, nil
+}
+
+// SubmitWithContext se comporte comme Submit mais fusionne initialContext
+// (le corps et les en-têtes d'un déclencheur externe, par exemple) dans les
+// paramètres de chaque étape sous la clé "trigger" avant de les exécuter.
+func (e *Executor) SubmitWithContext(workflowID string, steps []ActionStep, initialContext map[string]interface{}) (string, error) {
+	return e.Submit(workflowID, withTriggerContext(steps, initialContext))
+}
+
+func withTriggerContext(steps []ActionStep, ctx map[string]interface{}) []ActionStep {
+	if len(ctx) == 0 {
+		return steps
+	}
+	merged := make([]ActionStep, len(steps))
+	for i, step := range steps {
+		params := make(map[string]interface{}, len(step.Params)+1)
+		for k, v := range step.Params {
+			params[k] = v
+		}
+		params["trigger"] = ctx
+		merged[i] = ActionStep{Name: step.Name, Type: step.Type, Params: params, Retry: step.Retry}
+	}
+	return merged
+}
+
+// GetRun retourne l'état courant d'un run.
+func (e *Executor) GetRun(id string) (Run, error) {
+	return e.store.GetRun(id)
+}
+
+func (e *Executor) runSteps(run Run, steps []ActionStep) {
+	_ = e.store.UpdateRunStatus(run.ID, "running")
+
+	failed := false
+	for _, step := range steps {
+		result := e.runStep(step)
+		_ = e.store.AppendRunStep(run.ID, result)
+		if result.Status != "success" {
+			failed = true
+		}
+	}
+
+	status := "success"
+	if failed {
+		status = "failed"
+	}
+	_ = e.store.UpdateRunStatus(run.ID, status)
+}
+
+func (e *Executor) runStep(step ActionStep) StepResult {
+	runner, ok := e.registry.Get(step.Type)
+	if !ok {
+		return StepResult{Name: step.Name, Status: "failed", Error: fmt.Sprintf("unknown action type %q", step.Type)}
+	}
+
+	output, err := e.runWithRetry(runner, step.Params, step.Retry)
+	if err != nil {
+		return StepResult{Name: step.Name, Status: "failed", Error: err.Error()}
+	}
+	return StepResult{Name: step.Name, Status: "success", Output: output}
+}
+
+func (e *Executor) runWithRetry(runner ActionRunner, params map[string]interface{}, retry *RetryPolicy) (string, error) {
+	attempts := 1
+	backoff := 0 * time.Millisecond
+	if retry != nil {
+		if retry.MaxAttempts > 0 {
+			attempts = retry.MaxAttempts
+		}
+		backoff = time.Duration(retry.InitialBackoff) * time.Millisecond
+	}
+
+	var lastErr error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		output, err := runner.Run(context.Background(), params)
+		if err == nil {
+			return output, nil
+		}
+		lastErr = err
+		if attempt < attempts {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+	}
+	return "", lastErr
+}
+
+// NewID génère un identifiant aléatoire hexadécimal, utilisé comme ID de
+// run, de workflow ou de token.
+func NewID() string {
+	buf := make([]byte, 16)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
@@ -0,0 +1,117 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// countingRunner échoue les n-1 premières fois puis réussit, en notant le
+// nombre d'appels et le temps écoulé entre eux.
+type countingRunner struct {
+	failUntil int
+	calls     int
+	callTimes []time.Time
+}
+
+func (r *countingRunner) Run(ctx context.Context, params map[string]interface{}) (string, error) {
+	r.calls++
+	r.callTimes = append(r.callTimes, time.Now())
+	if r.calls < r.failUntil {
+		return "", errors.New("boom")
+	}
+	return "ok", nil
+}
+
+func TestRunWithRetrySucceedsWithinMaxAttempts(t *testing.T) {
+	e := &Executor{}
+	runner := &countingRunner{failUntil: 3}
+
+	output, err := e.runWithRetry(runner, nil, &RetryPolicy{MaxAttempts: 5, InitialBackoff: 1})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+	if output != "ok" {
+		t.Fatalf("expected output %q, got %q", "ok", output)
+	}
+	if runner.calls != 3 {
+		t.Fatalf("expected 3 calls, got %d", runner.calls)
+	}
+}
+
+func TestRunWithRetryExhaustsMaxAttempts(t *testing.T) {
+	e := &Executor{}
+	runner := &countingRunner{failUntil: 100}
+
+	_, err := e.runWithRetry(runner, nil, &RetryPolicy{MaxAttempts: 3, InitialBackoff: 1})
+	if err == nil {
+		t.Fatal("expected an error after exhausting attempts")
+	}
+	if runner.calls != 3 {
+		t.Fatalf("expected 3 calls, got %d", runner.calls)
+	}
+}
+
+func TestRunWithRetryBackoffDoublesBetweenAttempts(t *testing.T) {
+	e := &Executor{}
+	runner := &countingRunner{failUntil: 100}
+
+	start := time.Now()
+	_, _ = e.runWithRetry(runner, nil, &RetryPolicy{MaxAttempts: 3, InitialBackoff: 10})
+	elapsed := time.Since(start)
+
+	// Deux pauses : 10ms puis 20ms (doublée), soit au moins 30ms au total.
+	if elapsed < 30*time.Millisecond {
+		t.Fatalf("expected backoff to double between attempts (>= 30ms), took %v", elapsed)
+	}
+}
+
+func TestRunWithRetryNoPolicyRunsOnce(t *testing.T) {
+	e := &Executor{}
+	runner := &countingRunner{failUntil: 100}
+
+	_, err := e.runWithRetry(runner, nil, nil)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if runner.calls != 1 {
+		t.Fatalf("expected 1 call with no retry policy, got %d", runner.calls)
+	}
+}
+
+func TestWithTriggerContextMergesIntoEachStep(t *testing.T) {
+	steps := []ActionStep{
+		{Name: "a", Type: "log_message", Params: map[string]interface{}{"msg": "hi"}},
+		{Name: "b", Type: "log_message", Params: map[string]interface{}{"msg": "there"}},
+	}
+	ctx := map[string]interface{}{"body": "payload"}
+
+	merged := withTriggerContext(steps, ctx)
+
+	if len(merged) != 2 {
+		t.Fatalf("expected 2 steps, got %d", len(merged))
+	}
+	for i, step := range merged {
+		if step.Params["trigger"] == nil {
+			t.Fatalf("step %d: expected trigger context to be set", i)
+		}
+		if step.Params["msg"] != steps[i].Params["msg"] {
+			t.Fatalf("step %d: original params were not preserved", i)
+		}
+	}
+	// L'original ne doit pas être modifié.
+	if _, ok := steps[0].Params["trigger"]; ok {
+		t.Fatal("expected original steps to be left untouched")
+	}
+}
+
+func TestWithTriggerContextNoopWhenContextEmpty(t *testing.T) {
+	steps := []ActionStep{{Name: "a", Type: "log_message", Params: map[string]interface{}{"msg": "hi"}}}
+
+	merged := withTriggerContext(steps, nil)
+
+	if len(merged) != 1 || merged[0].Params["trigger"] != nil {
+		t.Fatal("expected steps to pass through unchanged when context is empty")
+	}
+}
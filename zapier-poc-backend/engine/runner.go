@@ -0,0 +1,57 @@
+package engine
+
+import "context"
+
+// ActionStep décrit une étape d'un workflow : le type d'action à exécuter
+// (clé de lookup dans la Registry), son nom d'affichage, ses paramètres et
+// sa politique de retry optionnelle.
+type ActionStep struct {
+	Name   string                 `json:"name"`
+	Type   string                 `json:"type"`
+	Params map[string]interface{} `json:"params"`
+	Retry  *RetryPolicy           `json:"retry,omitempty"`
+}
+
+// RetryPolicy configure le nombre de tentatives et le backoff exponentiel
+// appliqués à une étape en cas d'échec.
+type RetryPolicy struct {
+	MaxAttempts    int `json:"max_attempts"`
+	InitialBackoff int `json:"initial_backoff_ms"`
+}
+
+// ActionRunner exécute une action concrète à partir de ses paramètres et
+// renvoie un résultat texte ou une erreur.
+type ActionRunner interface {
+	Run(ctx context.Context, params map[string]interface{}) (string, error)
+}
+
+// Registry associe un type d'action (ex: "send_email") à son ActionRunner.
+type Registry struct {
+	runners map[string]ActionRunner
+}
+
+// NewRegistry crée une Registry vide.
+func NewRegistry() *Registry {
+	return &Registry{runners: make(map[string]ActionRunner)}
+}
+
+// NewDefaultRegistry crée une Registry pré-remplie avec les trois actions
+// de base du POC : envoi d'email, log et webhook.
+func NewDefaultRegistry() *Registry {
+	r := NewRegistry()
+	r.Register("send_email", &SendEmailRunner{})
+	r.Register("log_message", &LogMessageRunner{})
+	r.Register("webhook", &WebhookRunner{})
+	return r
+}
+
+// Register enregistre un ActionRunner pour un type d'action donné.
+func (r *Registry) Register(actionType string, runner ActionRunner) {
+	r.runners[actionType] = runner
+}
+
+// Get retourne l'ActionRunner associé à un type d'action, si connu.
+func (r *Registry) Get(actionType string) (ActionRunner, bool) {
+	runner, ok := r.runners[actionType]
+	return runner, ok
+}
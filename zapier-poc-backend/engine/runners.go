@@ -0,0 +1,111 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/smtp"
+	"os"
+)
+
+// SendEmailRunner envoie un email via SMTP. Les paramètres attendus sont
+// "to", "subject" et "body" ; la configuration du serveur SMTP est lue
+// depuis les variables d'environnement SMTP_HOST, SMTP_PORT, SMTP_FROM et,
+// si nécessaire, SMTP_USER / SMTP_PASSWORD.
+type SendEmailRunner struct{}
+
+func (r *SendEmailRunner) Run(ctx context.Context, params map[string]interface{}) (string, error) {
+	to, _ := params["to"].(string)
+	if to == "" {
+		return "", fmt.Errorf("send_email: missing \"to\" param")
+	}
+	subject, _ := params["subject"].(string)
+	body, _ := params["body"].(string)
+
+	host := os.Getenv("SMTP_HOST")
+	port := os.Getenv("SMTP_PORT")
+	from := os.Getenv("SMTP_FROM")
+	if host == "" || port == "" || from == "" {
+		return "", fmt.Errorf("send_email: SMTP_HOST, SMTP_PORT and SMTP_FROM must be set")
+	}
+
+	msg := []byte(fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\n\r\n%s\r\n", from, to, subject, body))
+
+	var auth smtp.Auth
+	if user := os.Getenv("SMTP_USER"); user != "" {
+		auth = smtp.PlainAuth("", user, os.Getenv("SMTP_PASSWORD"), host)
+	}
+
+	addr := host + ":" + port
+	if err := smtp.SendMail(addr, auth, from, []string{to}, msg); err != nil {
+		return "", fmt.Errorf("send_email: %w", err)
+	}
+	return fmt.Sprintf("email sent to %s", to), nil
+}
+
+// LogMessageRunner écrit un message dans les logs du serveur. Paramètre
+// attendu : "message".
+type LogMessageRunner struct{}
+
+func (r *LogMessageRunner) Run(ctx context.Context, params map[string]interface{}) (string, error) {
+	message, _ := params["message"].(string)
+	log.Println("[workflow]", message)
+	return message, nil
+}
+
+// WebhookRunner effectue une requête HTTP sortante. Paramètres attendus :
+// "url" (obligatoire), "method" (défaut POST), "headers"
+// (map[string]interface{}, seules les valeurs chaîne sont appliquées) et "body" (sérialisé en JSON s'il n'est pas déjà une chaîne).
+type WebhookRunner struct{}
+
+func (r *WebhookRunner) Run(ctx context.Context, params map[string]interface{}) (string, error) {
+	url, _ := params["url"].(string)
+	if url == "" {
+		return "", fmt.Errorf("webhook: missing \"url\" param")
+	}
+	method, _ := params["method"].(string)
+	if method == "" {
+		method = http.MethodPost
+	}
+
+	var payload []byte
+	switch body := params["body"].(type) {
+	case string:
+		payload = []byte(body)
+	case nil:
+		payload = nil
+	default:
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return "", fmt.Errorf("webhook: encoding body: %w", err)
+		}
+		payload = encoded
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(payload))
+	if err != nil {
+		return "", fmt.Errorf("webhook: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if headers, ok := params["headers"].(map[string]interface{}); ok {
+		for key, value := range headers {
+			if str, ok := value.(string); ok {
+				req.Header.Set(key, str)
+			}
+		}
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return "", fmt.Errorf("webhook: remote returned status %d", resp.StatusCode)
+	}
+	return fmt.Sprintf("webhook %s %s -> %d", method, url, resp.StatusCode), nil
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"net/http"
+
+	"zapierpoc/auth"
+	"zapierpoc/models"
+	"zapierpoc/store"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// AuthHandler expose l'inscription et la connexion des utilisateurs.
+type AuthHandler struct {
+	store store.Store
+}
+
+// NewAuthHandler construit un AuthHandler pour le store donné.
+func NewAuthHandler(s store.Store) *AuthHandler {
+	return &AuthHandler{store: s}
+}
+
+type credentials struct {
+	Name     string `json:"name"`
+	Email    string `json:"email" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+// Register crée un utilisateur avec un mot de passe haché en bcrypt et
+// renvoie un JWT.
+func (h *AuthHandler) Register(c *gin.Context) {
+	var input credentials
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if _, err := h.store.GetUserByEmail(input.Email); err == nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "email already registered"})
+		return
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(input.Password), bcrypt.DefaultCost)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.store.CreateUser(models.User{Name: input.Name, Email: input.Email, PasswordHash: string(hash)})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	token, err := auth.GenerateToken(user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"token": token, "user": user})
+}
+
+// Login vérifie les identifiants et renvoie un JWT.
+func (h *AuthHandler) Login(c *gin.Context) {
+	var input credentials
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.store.GetUserByEmail(input.Email)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid credentials"})
+		return
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(input.Password)); err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid credentials"})
+		return
+	}
+
+	token, err := auth.GenerateToken(user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"token": token, "user": user})
+}
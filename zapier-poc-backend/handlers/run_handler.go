@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"net/http"
+
+	"zapierpoc/engine"
+	"zapierpoc/store"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RunHandler expose la lecture du statut des runs de workflow.
+type RunHandler struct {
+	executor *engine.Executor
+	store    store.Store
+}
+
+// NewRunHandler construit un RunHandler pour l'executor et le store donnés.
+func NewRunHandler(executor *engine.Executor, s store.Store) *RunHandler {
+	return &RunHandler{executor: executor, store: s}
+}
+
+// Get renvoie le statut et les résultats d'un run possédé par l'appelant.
+func (h *RunHandler) Get(c *gin.Context) {
+	id := c.Param("id")
+	run, err := h.executor.GetRun(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Run not found"})
+		return
+	}
+
+	wf, err := h.store.GetWorkflow(run.WorkflowID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Run not found"})
+		return
+	}
+	if wf.OwnerID != c.GetString("userID") {
+		c.JSON(http.StatusForbidden, gin.H{"error": "not the workflow owner"})
+		return
+	}
+
+	c.JSON(http.StatusOK, run)
+}
@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"zapierpoc/models"
+	"zapierpoc/store"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	defaultUserListLimit = 50
+	maxUserListLimit     = 500
+)
+
+var userListSortColumns = map[string]bool{
+	"id":   true,
+	"name": true,
+}
+
+// UserHandler expose le CRUD des utilisateurs, indépendamment du backend
+// SQL ou Mongo utilisé.
+type UserHandler struct {
+	store store.Store
+}
+
+// NewUserHandler construit un UserHandler pour le store donné.
+func NewUserHandler(s store.Store) *UserHandler {
+	return &UserHandler{store: s}
+}
+
+// List renvoie les utilisateurs, paginés, triés et filtrés selon les
+// paramètres de requête limit, offset, sort_column, sort_order et
+// name_like.
+func (h *UserHandler) List(c *gin.Context) {
+	limit := defaultUserListLimit
+	if raw := c.Query("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 || parsed > maxUserListLimit {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+			return
+		}
+		limit = parsed
+	}
+
+	offset := 0
+	if raw := c.Query("offset"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid offset"})
+			return
+		}
+		offset = parsed
+	}
+
+	sortColumn := c.DefaultQuery("sort_column", "id")
+	if !userListSortColumns[sortColumn] {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid sort_column"})
+		return
+	}
+
+	sortOrder := c.DefaultQuery("sort_order", "asc")
+	if !strings.EqualFold(sortOrder, "asc") && !strings.EqualFold(sortOrder, "desc") {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid sort_order"})
+		return
+	}
+
+	opts := store.ListUsersOptions{
+		Limit:      limit,
+		Offset:     offset,
+		SortColumn: sortColumn,
+		SortOrder:  sortOrder,
+		NameLike:   c.Query("name_like"),
+	}
+
+	users, total, err := h.store.ListUsers(opts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"items": users, "total": total, "limit": limit, "offset": offset})
+}
+
+// Update met à jour le nom d'un utilisateur, qui doit être l'appelant
+// authentifié.
+func (h *UserHandler) Update(c *gin.Context) {
+	id := c.Param("id")
+	if id != c.GetString("userID") {
+		c.JSON(http.StatusForbidden, gin.H{"error": "not the account owner"})
+		return
+	}
+
+	var input struct {
+		Name string `json:"name"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	user, err := h.store.UpdateUser(models.User{ID: id, Name: input.Name})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, user)
+}
+
+// Delete supprime le compte de l'appelant, après avoir vérifié qu'il en
+// est bien le propriétaire et qu'il a resaisi son mot de passe.
+func (h *UserHandler) Delete(c *gin.Context) {
+	id := c.Param("id")
+	if id != c.GetString("userID") {
+		c.JSON(http.StatusForbidden, gin.H{"error": "not the account owner"})
+		return
+	}
+
+	var input struct {
+		Password string `json:"password" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.store.GetUser(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		return
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(input.Password)); err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid password"})
+		return
+	}
+
+	if err := h.store.DeleteUser(id); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "deleted"})
+}
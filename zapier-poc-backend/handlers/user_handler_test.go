@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"zapierpoc/engine"
+	"zapierpoc/models"
+	"zapierpoc/store"
+
+	"github.com/gin-gonic/gin"
+)
+
+// fakeStore est un store.Store minimal en mémoire, suffisant pour tester
+// les handlers sans base de données réelle.
+type fakeStore struct {
+	users           map[string]models.User
+	lastListOptions store.ListUsersOptions
+}
+
+func newFakeStore(users ...models.User) *fakeStore {
+	s := &fakeStore{users: map[string]models.User{}}
+	for _, u := range users {
+		s.users[u.ID] = u
+	}
+	return s
+}
+
+func (s *fakeStore) CreateWorkflow(wf models.Workflow) (models.Workflow, error) { return wf, nil }
+func (s *fakeStore) GetWorkflow(id string) (models.Workflow, error) {
+	return models.Workflow{}, errors.New("not found")
+}
+func (s *fakeStore) GetWorkflowByTriggerToken(token string) (models.Workflow, error) {
+	return models.Workflow{}, errors.New("not found")
+}
+func (s *fakeStore) ListWorkflows() ([]models.Workflow, error) { return nil, nil }
+func (s *fakeStore) ListWorkflowsByOwner(ownerID string) ([]models.Workflow, error) {
+	return nil, nil
+}
+func (s *fakeStore) UpdateWorkflow(wf models.Workflow) (models.Workflow, error) { return wf, nil }
+func (s *fakeStore) DeleteWorkflow(id string) error { return nil }
+
+func (s *fakeStore) CreateRun(run engine.Run) (engine.Run, error) { return run, nil }
+func (s *fakeStore) UpdateRunStatus(runID string, status string) error { return nil }
+func (s *fakeStore) AppendRunStep(runID string, step engine.StepResult) error {
+	return nil
+}
+func (s *fakeStore) GetRun(id string) (engine.Run, error) { return engine.Run{}, nil }
+
+func (s *fakeStore) CreateUser(user models.User) (models.User, error) { return user, nil }
+func (s *fakeStore) GetUser(id string) (models.User, error) {
+	user, ok := s.users[id]
+	if !ok {
+		return models.User{}, errors.New("not found")
+	}
+	return user, nil
+}
+func (s *fakeStore) GetUserByEmail(email string) (models.User, error) {
+	return models.User{}, errors.New("not found")
+}
+func (s *fakeStore) ListUsers(opts store.ListUsersOptions) ([]models.User, int64, error) {
+	s.lastListOptions = opts
+	users := make([]models.User, 0, len(s.users))
+	for _, u := range s.users {
+		users = append(users, u)
+	}
+	return users, int64(len(users)), nil
+}
+func (s *fakeStore) UpdateUser(user models.User) (models.User, error) {
+	existing, ok := s.users[user.ID]
+	if !ok {
+		return models.User{}, errors.New("not found")
+	}
+	existing.Name = user.Name
+	s.users[user.ID] = existing
+	return existing, nil
+}
+func (s *fakeStore) DeleteUser(id string) error {
+	delete(s.users, id)
+	return nil
+}
+
+func newUserTestContext(method, target string, body []byte) (*gin.Context, *httptest.ResponseRecorder) {
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(method, target, bytes.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	return c, rec
+}
+
+func TestUserHandlerListRejectsInvalidSortColumn(t *testing.T) {
+	h := NewUserHandler(newFakeStore())
+	c, rec := newUserTestContext(http.MethodGet, "/users?sort_column=password_hash", nil)
+
+	h.List(c)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for a non-whitelisted sort_column, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestUserHandlerListRejectsInvalidSortOrder(t *testing.T) {
+	h := NewUserHandler(newFakeStore())
+	c, rec := newUserTestContext(http.MethodGet, "/users?sort_order=sideways", nil)
+
+	h.List(c)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for an invalid sort_order, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestUserHandlerListPassesWhitelistedOptionsThrough(t *testing.T) {
+	s := newFakeStore()
+	h := NewUserHandler(s)
+	c, rec := newUserTestContext(http.MethodGet, "/users?sort_column=name&sort_order=desc&limit=10&offset=5&name_like=al", nil)
+
+	h.List(c)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if s.lastListOptions.SortColumn != "name" || s.lastListOptions.SortOrder != "desc" {
+		t.Fatalf("sort options not passed through: %+v", s.lastListOptions)
+	}
+	if s.lastListOptions.Limit != 10 || s.lastListOptions.Offset != 5 || s.lastListOptions.NameLike != "al" {
+		t.Fatalf("pagination/filter options not passed through: %+v", s.lastListOptions)
+	}
+}
+
+func TestUserHandlerUpdateRejectsNonOwner(t *testing.T) {
+	s := newFakeStore(models.User{ID: "victim", Name: "Original"})
+	h := NewUserHandler(s)
+	c, rec := newUserTestContext(http.MethodPut, "/users/victim", []byte(`{"name":"PWNED"}`))
+	c.Params = gin.Params{{Key: "id", Value: "victim"}}
+	c.Set("userID", "attacker")
+
+	h.Update(c)
+
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 when caller is not the account owner, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if s.users["victim"].Name != "Original" {
+		t.Fatalf("expected victim's name to be unchanged, got %q", s.users["victim"].Name)
+	}
+}
+
+func TestUserHandlerUpdateAllowsOwner(t *testing.T) {
+	s := newFakeStore(models.User{ID: "owner", Name: "Original"})
+	h := NewUserHandler(s)
+	c, rec := newUserTestContext(http.MethodPut, "/users/owner", []byte(`{"name":"Updated"}`))
+	c.Params = gin.Params{{Key: "id", Value: "owner"}}
+	c.Set("userID", "owner")
+
+	h.Update(c)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if s.users["owner"].Name != "Updated" {
+		t.Fatalf("expected owner's name to be updated, got %q", s.users["owner"].Name)
+	}
+}
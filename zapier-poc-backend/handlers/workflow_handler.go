@@ -0,0 +1,177 @@
+// Package handlers expose les endpoints Gin pour les workflows, leurs runs
+// et les utilisateurs, branchés sur un store.Store quel que soit le
+// backend choisi.
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"zapierpoc/engine"
+	"zapierpoc/models"
+	"zapierpoc/store"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CronScheduler est la vue qu'un WorkflowHandler a sur le trigger cron :
+// de quoi (re)programmer ou retirer la planification d'un workflow quand
+// il est créé, modifié ou supprimé après le démarrage.
+type CronScheduler interface {
+	Schedule(wf models.Workflow) error
+	Unschedule(workflowID string)
+}
+
+// WorkflowHandler expose le CRUD et l'exécution des workflows. Chaque
+// workflow appartient à l'utilisateur authentifié qui l'a créé.
+type WorkflowHandler struct {
+	store    store.Store
+	executor *engine.Executor
+	cron     CronScheduler
+}
+
+// NewWorkflowHandler construit un WorkflowHandler pour le store,
+// l'executor et le scheduler cron donnés.
+func NewWorkflowHandler(s store.Store, executor *engine.Executor, cron CronScheduler) *WorkflowHandler {
+	return &WorkflowHandler{store: s, executor: executor, cron: cron}
+}
+
+// List ne renvoie que les workflows appartenant à l'appelant.
+func (h *WorkflowHandler) List(c *gin.Context) {
+	workflows, err := h.store.ListWorkflowsByOwner(c.GetString("userID"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, workflows)
+}
+
+// Create persiste un nouveau workflow pour l'appelant. Un trigger de type
+// "webhook" se voit attribuer un token d'URL généré aléatoirement.
+func (h *WorkflowHandler) Create(c *gin.Context) {
+	var wf models.Workflow
+	if err := c.ShouldBindJSON(&wf); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	wf.OwnerID = c.GetString("userID")
+	if wf.Trigger != nil && wf.Trigger.Type == "webhook" {
+		wf.TriggerToken = engine.NewID()
+	}
+	created, err := h.store.CreateWorkflow(wf)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.cron.Schedule(created); err != nil {
+		log.Printf("workflow %s: invalid cron schedule: %v", created.ID, err)
+	}
+	c.JSON(http.StatusCreated, created)
+}
+
+// Update remplace le nom, les étapes et le trigger d'un workflow possédé
+// par l'appelant. Un nouveau trigger de type "webhook" se voit attribuer
+// un token d'URL généré aléatoirement ; tout autre type perd son token.
+func (h *WorkflowHandler) Update(c *gin.Context) {
+	existing, ok := h.ownedWorkflow(c)
+	if !ok {
+		return
+	}
+
+	var input models.Workflow
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	existing.Name = input.Name
+	existing.Steps = input.Steps
+	existing.Trigger = input.Trigger
+	if existing.Trigger != nil && existing.Trigger.Type == "webhook" {
+		if existing.TriggerToken == "" {
+			existing.TriggerToken = engine.NewID()
+		}
+	} else {
+		existing.TriggerToken = ""
+	}
+
+	updated, err := h.store.UpdateWorkflow(existing)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.cron.Schedule(updated); err != nil {
+		log.Printf("workflow %s: invalid cron schedule: %v", updated.ID, err)
+	}
+	c.JSON(http.StatusOK, updated)
+}
+
+// Delete supprime un workflow possédé par l'appelant.
+func (h *WorkflowHandler) Delete(c *gin.Context) {
+	existing, ok := h.ownedWorkflow(c)
+	if !ok {
+		return
+	}
+	if err := h.store.DeleteWorkflow(existing.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	h.cron.Unschedule(existing.ID)
+	c.JSON(http.StatusOK, gin.H{"status": "deleted"})
+}
+
+// DeleteTrigger révoque le trigger d'un workflow possédé par l'appelant
+// (et son token webhook éventuel).
+func (h *WorkflowHandler) DeleteTrigger(c *gin.Context) {
+	existing, ok := h.ownedWorkflow(c)
+	if !ok {
+		return
+	}
+
+	existing.Trigger = nil
+	existing.TriggerToken = ""
+	updated, err := h.store.UpdateWorkflow(existing)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	h.cron.Unschedule(updated.ID)
+	c.JSON(http.StatusOK, updated)
+}
+
+// Execute enqueue l'exécution asynchrone d'un workflow possédé par
+// l'appelant et retourne immédiatement l'ID du run à suivre via
+// GET /runs/:id.
+func (h *WorkflowHandler) Execute(c *gin.Context) {
+	wf, ok := h.ownedWorkflow(c)
+	if !ok {
+		return
+	}
+
+	runID, err := h.executor.Submit(wf.ID, wf.Steps)
+	if err != nil {
+		if errors.Is(err, engine.ErrQueueFull) {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusAccepted, gin.H{"run_id": runID})
+}
+
+// ownedWorkflow charge le workflow désigné par l'URL et vérifie que
+// l'appelant en est le propriétaire. En cas d'échec elle écrit la réponse
+// HTTP appropriée (404 ou 403) et renvoie ok=false.
+func (h *WorkflowHandler) ownedWorkflow(c *gin.Context) (models.Workflow, bool) {
+	wf, err := h.store.GetWorkflow(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Workflow not found"})
+		return models.Workflow{}, false
+	}
+	if wf.OwnerID != c.GetString("userID") {
+		c.JSON(http.StatusForbidden, gin.H{"error": "not the workflow owner"})
+		return models.Workflow{}, false
+	}
+	return wf, true
+}
@@ -1,48 +1,65 @@
 package main
 
 import (
+	"log"
 	"net/http"
+	"os"
+
+	"zapierpoc/engine"
+	"zapierpoc/handlers"
+	"zapierpoc/middleware"
 	pocbacknosql "zapierpoc/poc-back-nosql"
 	pocbacksql "zapierpoc/poc-back-sql"
+	"zapierpoc/store"
+	"zapierpoc/store/sqlstore"
+	"zapierpoc/trigger"
 
 	"github.com/gin-gonic/gin"
 )
 
-type Workflow struct {
-	ID      string   `json:"id"`
-	Name    string   `json:"name"`
-	Actions []string `json:"actions"`
-}
-
+// Action est l'action disponible renvoyée par GET /actions.
 type Action struct {
 	ID   string `json:"id"`
 	Name string `json:"name"`
 }
 
-var workflows = []Workflow{}
 var actions = []Action{
 	{ID: "1", Name: "Send Email"},
 	{ID: "2", Name: "Log Message"},
 	{ID: "3", Name: "Webhook"},
 }
 
-func executeWorkflow(c *gin.Context) {
-	id := c.Param("id")
-	for _, wf := range workflows {
-		if wf.ID == id {
-			// Simule l'exécution de chaque action
-			result := []string{}
-			for _, action := range wf.Actions {
-				result = append(result, "Action exécutée: "+action)
-			}
-			c.JSON(http.StatusOK, gin.H{"result": result})
-			return
+// initStore choisit le backend de persistance à partir de la variable
+// d'environnement STORAGE_BACKEND ("sql" par défaut, ou "mongo").
+func initStore() (store.Store, *sqlstore.SQLStore) {
+	if os.Getenv("STORAGE_BACKEND") == "mongo" {
+		mongoStore, err := pocbacknosql.InitMongo()
+		if err != nil {
+			log.Fatalf("failed to connect to mongo: %v", err)
 		}
+		return mongoStore, nil
+	}
+
+	sqlStore, err := pocbacksql.InitSQL()
+	if err != nil {
+		log.Fatalf("failed to connect to sql database: %v", err)
 	}
-	c.JSON(http.StatusNotFound, gin.H{"error": "Workflow not found"})
+	return sqlStore, sqlStore
 }
 
 func main() {
+	backend, sqlBackend := initStore()
+
+	executor := engine.NewExecutor(4, engine.NewDefaultRegistry(), backend)
+	executor.Start()
+
+	cronTrigger := trigger.NewCronTrigger(backend)
+
+	workflowHandler := handlers.NewWorkflowHandler(backend, executor, cronTrigger)
+	runHandler := handlers.NewRunHandler(executor, backend)
+	userHandler := handlers.NewUserHandler(backend)
+	authHandler := handlers.NewAuthHandler(backend)
+
 	r := gin.Default()
 
 	r.Use(func(c *gin.Context) {
@@ -56,39 +73,39 @@ func main() {
 		c.Next()
 	})
 
-	r.GET("/workflows", func(c *gin.Context) {
-		c.JSON(http.StatusOK, workflows)
-	})
+	r.POST("/auth/register", authHandler.Register)
+	r.POST("/auth/login", authHandler.Login)
 
-	r.POST("/workflows", func(c *gin.Context) {
-		var wf Workflow
-		if err := c.ShouldBindJSON(&wf); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-			return
-		}
-		workflows = append(workflows, wf)
-		c.JSON(http.StatusCreated, wf)
-	})
+	workflows := r.Group("/workflows", middleware.AuthRequired())
+	workflows.GET("", workflowHandler.List)
+	workflows.POST("", workflowHandler.Create)
+	workflows.PUT("/:id", workflowHandler.Update)
+	workflows.DELETE("/:id", workflowHandler.Delete)
+	workflows.POST("/:id/execute", workflowHandler.Execute)
+	workflows.DELETE("/:id/trigger", workflowHandler.DeleteTrigger)
+
+	r.GET("/runs/:id", middleware.AuthRequired(), runHandler.Get)
+
+	webhookTrigger := trigger.NewWebhookTrigger(backend)
+	if err := webhookTrigger.Register(r, executor); err != nil {
+		log.Fatalf("failed to register webhook trigger: %v", err)
+	}
+
+	if err := cronTrigger.Register(r, executor); err != nil {
+		log.Fatalf("failed to register cron trigger: %v", err)
+	}
 
 	r.GET("/actions", func(c *gin.Context) {
 		c.JSON(http.StatusOK, actions)
 	})
 
-	r.POST("/workflows/:id/execute", executeWorkflow)
-
-	pocbacksql.InitSQL()
-	pocbacknosql.InitMongo()
+	r.GET("/users", middleware.AuthRequired(), userHandler.List)
+	r.PUT("/users/:id", middleware.AuthRequired(), userHandler.Update)
+	r.DELETE("/users/:id", middleware.AuthRequired(), userHandler.Delete)
 
-	r.POST("/users/sql", pocbacksql.CreateUserSQL)
-	r.GET("/users/sql", pocbacksql.GetUsersSQL)
-	r.PUT("/users/sql/:id", pocbacksql.UpdateUserSQL)
-	r.DELETE("/users/sql/:id", pocbacksql.DeleteUserSQL)
-	r.POST("/users/sql/test-add", pocbacksql.AddTestUsersSQL)
-
-	r.POST("/users/nosql", pocbacknosql.CreateUserNoSQL)
-	r.GET("/users/nosql", pocbacknosql.GetUsersNoSQL)
-	r.PUT("/users/nosql/:id", pocbacknosql.UpdateUserNoSQL)
-	r.DELETE("/users/nosql/:id", pocbacknosql.DeleteUserNoSQL)
+	if sqlBackend != nil {
+		r.POST("/users/sql/test-add", pocbacksql.AddTestUsersHandler(sqlBackend))
+	}
 
 	r.Run(":8080")
 }
@@ -0,0 +1,35 @@
+// Package middleware regroupe les middlewares Gin partagés par les routes
+// de l'API.
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"zapierpoc/auth"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuthRequired vérifie l'en-tête "Authorization: Bearer <token>", et
+// stocke l'ID utilisateur authentifié dans le contexte Gin sous la clé
+// "userID". Répond 401 si l'en-tête est absent ou le token invalide.
+func AuthRequired() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		token, ok := strings.CutPrefix(header, "Bearer ")
+		if !ok || token == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "missing bearer token"})
+			return
+		}
+
+		userID, err := auth.ParseUserID(token)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
+			return
+		}
+
+		c.Set("userID", userID)
+		c.Next()
+	}
+}
@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"zapierpoc/auth"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newAuthTestRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/whoami", AuthRequired(), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"userID": c.GetString("userID")})
+	})
+	return r
+}
+
+func TestAuthRequiredRejectsMissingHeader(t *testing.T) {
+	r := newAuthTestRouter()
+	req := httptest.NewRequest(http.MethodGet, "/whoami", nil)
+	rec := httptest.NewRecorder()
+
+	r.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", rec.Code)
+	}
+}
+
+func TestAuthRequiredRejectsInvalidToken(t *testing.T) {
+	r := newAuthTestRouter()
+	req := httptest.NewRequest(http.MethodGet, "/whoami", nil)
+	req.Header.Set("Authorization", "Bearer not-a-valid-token")
+	rec := httptest.NewRecorder()
+
+	r.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", rec.Code)
+	}
+}
+
+func TestAuthRequiredAcceptsValidTokenAndSetsUserID(t *testing.T) {
+	token, err := auth.GenerateToken("user-7")
+	if err != nil {
+		t.Fatalf("GenerateToken: %v", err)
+	}
+
+	r := newAuthTestRouter()
+	req := httptest.NewRequest(http.MethodGet, "/whoami", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+
+	r.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if body := rec.Body.String(); body != `{"userID":"user-7"}` {
+		t.Fatalf("unexpected body: %s", body)
+	}
+}
@@ -0,0 +1,36 @@
+// Package models regroupe les types métier partagés entre les handlers et
+// les implémentations de store, indépendamment du backend de persistance.
+package models
+
+import "zapierpoc/engine"
+
+// Trigger décrit comment un workflow démarre automatiquement : son type
+// ("webhook", "cron", ...) et sa configuration spécifique (schedule cron,
+// etc).
+type Trigger struct {
+	Type   string                 `json:"type"`
+	Config map[string]interface{} `json:"config"`
+}
+
+// Workflow est la représentation persistée d'un workflow. TriggerToken
+// n'est renseigné que pour un Trigger de type "webhook" ; il est généré à
+// la création et sert de secret dans l'URL /triggers/webhook/:token.
+// OwnerID est l'ID de l'utilisateur qui l'a créé ; seul son propriétaire
+// peut le lire, le modifier, le supprimer ou l'exécuter.
+type Workflow struct {
+	ID           string              `json:"id"`
+	Name         string              `json:"name"`
+	Steps        []engine.ActionStep `json:"steps"`
+	Trigger      *Trigger            `json:"trigger,omitempty"`
+	TriggerToken string              `json:"trigger_token,omitempty"`
+	OwnerID      string              `json:"owner_id,omitempty"`
+}
+
+// User est la représentation persistée d'un utilisateur. PasswordHash
+// n'est jamais sérialisé en JSON.
+type User struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	Email        string `json:"email"`
+	PasswordHash string `json:"-"`
+}
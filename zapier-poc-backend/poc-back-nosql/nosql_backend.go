@@ -1,103 +1,20 @@
 package pocbacknosql
 
-// Backend Go : API REST CRUD NoSQL (MongoDB)
-// Ce fichier gère les utilisateurs en NoSQL
-
 import (
 	"context"
-	"net/http"
 
-	"github.com/gin-gonic/gin"
-	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/bson/primitive"
+	"zapierpoc/store/mongostore"
+
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-type User struct {
-	ID   primitive.ObjectID `bson:"_id,omitempty" json:"id"`
-	Name string `bson:"name" json:"name"`
-}
-
-var mongoClient *mongo.Client
-var mongoCollection *mongo.Collection
-
-// Initialise la connexion MongoDB et la collection
-func InitMongo() {
-	mongoClient, _ = mongo.Connect(context.TODO(), options.Client().ApplyURI("mongodb://localhost:27017"))
-	mongoCollection = mongoClient.Database("pocdb").Collection("users")
-}
-
-// Handler pour créer un utilisateur NoSQL
-func CreateUserNoSQL(c *gin.Context) {
-	var input struct {
-		Name string `json:"name"`
-	}
-	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
-	res, err := mongoCollection.InsertOne(context.TODO(), bson.M{"name": input.Name})
+// InitMongo se connecte à MongoDB et retourne un mongostore.MongoStore
+// prêt à être injecté dans les handlers.
+func InitMongo() (*mongostore.MongoStore, error) {
+	client, err := mongo.Connect(context.TODO(), options.Client().ApplyURI("mongodb://localhost:27017"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
-	}
-	c.JSON(http.StatusCreated, gin.H{"id": res.InsertedID, "name": input.Name})
-}
-
-// Handler pour lire tous les utilisateurs NoSQL
-func GetUsersNoSQL(c *gin.Context) {
-	cursor, err := mongoCollection.Find(context.TODO(), bson.M{})
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
-	}
-	defer cursor.Close(context.TODO())
-	var users []User
-	for cursor.Next(context.TODO()) {
-		var user User
-		if err := cursor.Decode(&user); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-			return
-		}
-		users = append(users, user)
+		return nil, err
 	}
-	c.JSON(http.StatusOK, users)
+	return mongostore.New(client.Database("pocdb")), nil
 }
-
-func UpdateUserNoSQL(c *gin.Context) {
-	id := c.Param("id")
-	objID, err := primitive.ObjectIDFromHex(id)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ID invalide"})
-		return
-	}
-	var input struct {
-		Name string `json:"name"`
-	}
-	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
-	_, err = mongoCollection.UpdateOne(context.TODO(), bson.M{"_id": objID}, bson.M{"$set": bson.M{"name": input.Name}})
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
-	}
-	c.JSON(http.StatusOK, gin.H{"id": id, "name": input.Name})
-}
-
-func DeleteUserNoSQL(c *gin.Context) {
-	id := c.Param("id")
-	objID, err := primitive.ObjectIDFromHex(id)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ID invalide"})
-		return
-	}
-	_, err = mongoCollection.DeleteOne(context.TODO(), bson.M{"_id": objID})
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
-	}
-	c.JSON(http.StatusOK, gin.H{"status": "deleted"})
-}
\ No newline at end of file
@@ -0,0 +1,253 @@
+// Package mongostore implémente store.Store au-dessus de collections
+// MongoDB, une par entité (workflows, runs, users).
+package mongostore
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"zapierpoc/engine"
+	"zapierpoc/models"
+	"zapierpoc/store"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type workflowDoc struct {
+	ID           string              `bson:"_id"`
+	Name         string              `bson:"name"`
+	Steps        []engine.ActionStep `bson:"steps"`
+	Trigger      *models.Trigger     `bson:"trigger,omitempty"`
+	TriggerToken string              `bson:"trigger_token,omitempty"`
+	OwnerID      string              `bson:"owner_id,omitempty"`
+}
+
+func (d workflowDoc) toModel() models.Workflow {
+	return models.Workflow{ID: d.ID, Name: d.Name, Steps: d.Steps, Trigger: d.Trigger, TriggerToken: d.TriggerToken, OwnerID: d.OwnerID}
+}
+
+func workflowDocFromModel(wf models.Workflow) workflowDoc {
+	return workflowDoc{ID: wf.ID, Name: wf.Name, Steps: wf.Steps, Trigger: wf.Trigger, TriggerToken: wf.TriggerToken, OwnerID: wf.OwnerID}
+}
+
+type runDoc struct {
+	ID         string              `bson:"_id"`
+	WorkflowID string              `bson:"workflow_id"`
+	Status     string              `bson:"status"`
+	Steps      []engine.StepResult `bson:"steps"`
+}
+
+type userDoc struct {
+	ID           string `bson:"_id"`
+	Name         string `bson:"name"`
+	Email        string `bson:"email"`
+	PasswordHash string `bson:"password_hash"`
+}
+
+func (d userDoc) toModel() models.User {
+	return models.User{ID: d.ID, Name: d.Name, Email: d.Email, PasswordHash: d.PasswordHash}
+}
+
+// MongoStore implémente store.Store au-dessus des collections
+// workflows/runs/users d'une base Mongo.
+type MongoStore struct {
+	workflows *mongo.Collection
+	runs      *mongo.Collection
+	users     *mongo.Collection
+}
+
+// New enveloppe les collections workflows/runs/users de la base donnée
+// dans un MongoStore.
+func New(db *mongo.Database) *MongoStore {
+	return &MongoStore{
+		workflows: db.Collection("workflows"),
+		runs:      db.Collection("runs"),
+		users:     db.Collection("users"),
+	}
+}
+
+func (s *MongoStore) CreateWorkflow(wf models.Workflow) (models.Workflow, error) {
+	if wf.ID == "" {
+		wf.ID = engine.NewID()
+	}
+	if _, err := s.workflows.InsertOne(context.TODO(), workflowDocFromModel(wf)); err != nil {
+		return models.Workflow{}, err
+	}
+	return wf, nil
+}
+
+func (s *MongoStore) GetWorkflow(id string) (models.Workflow, error) {
+	var doc workflowDoc
+	if err := s.workflows.FindOne(context.TODO(), bson.M{"_id": id}).Decode(&doc); err != nil {
+		return models.Workflow{}, err
+	}
+	return doc.toModel(), nil
+}
+
+func (s *MongoStore) GetWorkflowByTriggerToken(token string) (models.Workflow, error) {
+	var doc workflowDoc
+	if err := s.workflows.FindOne(context.TODO(), bson.M{"trigger_token": token}).Decode(&doc); err != nil {
+		return models.Workflow{}, err
+	}
+	return doc.toModel(), nil
+}
+
+func (s *MongoStore) ListWorkflows() ([]models.Workflow, error) {
+	return s.findWorkflows(bson.M{})
+}
+
+func (s *MongoStore) ListWorkflowsByOwner(ownerID string) ([]models.Workflow, error) {
+	return s.findWorkflows(bson.M{"owner_id": ownerID})
+}
+
+func (s *MongoStore) findWorkflows(filter bson.M) ([]models.Workflow, error) {
+	cursor, err := s.workflows.Find(context.TODO(), filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	var workflows []models.Workflow
+	for cursor.Next(context.TODO()) {
+		var doc workflowDoc
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		workflows = append(workflows, doc.toModel())
+	}
+	return workflows, nil
+}
+
+func (s *MongoStore) UpdateWorkflow(wf models.Workflow) (models.Workflow, error) {
+	doc := workflowDocFromModel(wf)
+	_, err := s.workflows.UpdateOne(context.TODO(), bson.M{"_id": wf.ID}, bson.M{"$set": bson.M{
+		"name":          doc.Name,
+		"steps":         doc.Steps,
+		"trigger":       doc.Trigger,
+		"trigger_token": doc.TriggerToken,
+		"owner_id":      doc.OwnerID,
+	}})
+	if err != nil {
+		return models.Workflow{}, err
+	}
+	return wf, nil
+}
+
+func (s *MongoStore) DeleteWorkflow(id string) error {
+	_, err := s.workflows.DeleteOne(context.TODO(), bson.M{"_id": id})
+	return err
+}
+
+func (s *MongoStore) CreateRun(run engine.Run) (engine.Run, error) {
+	if run.ID == "" {
+		run.ID = engine.NewID()
+	}
+	doc := runDoc{ID: run.ID, WorkflowID: run.WorkflowID, Status: run.Status, Steps: run.Steps}
+	if _, err := s.runs.InsertOne(context.TODO(), doc); err != nil {
+		return engine.Run{}, err
+	}
+	return run, nil
+}
+
+func (s *MongoStore) UpdateRunStatus(runID string, status string) error {
+	_, err := s.runs.UpdateOne(context.TODO(), bson.M{"_id": runID}, bson.M{"$set": bson.M{"status": status}})
+	return err
+}
+
+func (s *MongoStore) AppendRunStep(runID string, step engine.StepResult) error {
+	_, err := s.runs.UpdateOne(context.TODO(), bson.M{"_id": runID}, bson.M{"$push": bson.M{"steps": step}})
+	return err
+}
+
+func (s *MongoStore) GetRun(id string) (engine.Run, error) {
+	var doc runDoc
+	if err := s.runs.FindOne(context.TODO(), bson.M{"_id": id}).Decode(&doc); err != nil {
+		return engine.Run{}, err
+	}
+	return engine.Run{ID: doc.ID, WorkflowID: doc.WorkflowID, Status: doc.Status, Steps: doc.Steps}, nil
+}
+
+func (s *MongoStore) CreateUser(user models.User) (models.User, error) {
+	if user.ID == "" {
+		user.ID = engine.NewID()
+	}
+	doc := userDoc{ID: user.ID, Name: user.Name, Email: user.Email, PasswordHash: user.PasswordHash}
+	if _, err := s.users.InsertOne(context.TODO(), doc); err != nil {
+		return models.User{}, err
+	}
+	return user, nil
+}
+
+func (s *MongoStore) GetUser(id string) (models.User, error) {
+	var doc userDoc
+	if err := s.users.FindOne(context.TODO(), bson.M{"_id": id}).Decode(&doc); err != nil {
+		return models.User{}, err
+	}
+	return doc.toModel(), nil
+}
+
+func (s *MongoStore) GetUserByEmail(email string) (models.User, error) {
+	var doc userDoc
+	if err := s.users.FindOne(context.TODO(), bson.M{"email": email}).Decode(&doc); err != nil {
+		return models.User{}, err
+	}
+	return doc.toModel(), nil
+}
+
+func (s *MongoStore) ListUsers(opts store.ListUsersOptions) ([]models.User, int64, error) {
+	filter := bson.M{}
+	if opts.NameLike != "" {
+		filter["name"] = bson.M{"$regex": regexp.QuoteMeta(opts.NameLike), "$options": "i"}
+	}
+
+	total, err := s.users.CountDocuments(context.TODO(), filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	sortColumn := opts.SortColumn
+	if sortColumn == "" || sortColumn == "id" {
+		sortColumn = "_id"
+	}
+	dir := 1
+	if strings.EqualFold(opts.SortOrder, "desc") {
+		dir = -1
+	}
+
+	findOpts := options.Find().
+		SetLimit(int64(opts.Limit)).
+		SetSkip(int64(opts.Offset)).
+		SetSort(bson.D{{Key: sortColumn, Value: dir}})
+
+	cursor, err := s.users.Find(context.TODO(), filter, findOpts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(context.TODO())
+
+	var users []models.User
+	for cursor.Next(context.TODO()) {
+		var doc userDoc
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, 0, err
+		}
+		users = append(users, doc.toModel())
+	}
+	return users, total, nil
+}
+
+func (s *MongoStore) UpdateUser(user models.User) (models.User, error) {
+	_, err := s.users.UpdateOne(context.TODO(), bson.M{"_id": user.ID}, bson.M{"$set": bson.M{"name": user.Name}})
+	if err != nil {
+		return models.User{}, err
+	}
+	return user, nil
+}
+
+func (s *MongoStore) DeleteUser(id string) error {
+	_, err := s.users.DeleteOne(context.TODO(), bson.M{"_id": id})
+	return err
+}
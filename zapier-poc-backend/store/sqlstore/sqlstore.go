@@ -0,0 +1,298 @@
+// Package sqlstore implémente store.Store au-dessus d'une base gérée par
+// GORM.
+package sqlstore
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"zapierpoc/engine"
+	"zapierpoc/models"
+	"zapierpoc/store"
+
+	"gorm.io/gorm"
+)
+
+// workflowRow est la représentation GORM d'un workflow ; Steps est stocké
+// en JSON car GORM/sqlite ne sait pas nativement mapper un slice de
+// structs sur une colonne.
+type workflowRow struct {
+	ID            string `gorm:"primaryKey"`
+	Name          string
+	StepsJSON     string
+	TriggerType   string
+	TriggerConfig string
+	TriggerToken  string `gorm:"index"`
+	OwnerID       string `gorm:"index"`
+}
+
+type runRow struct {
+	ID         string `gorm:"primaryKey"`
+	WorkflowID string
+	Status     string
+}
+
+type runStepRow struct {
+	ID     uint `gorm:"primaryKey;autoIncrement"`
+	RunID  string
+	Name   string
+	Status string
+	Output string
+	Error  string
+}
+
+type userRow struct {
+	ID           uint `gorm:"primaryKey;autoIncrement"`
+	Name         string
+	Email        string `gorm:"uniqueIndex"`
+	PasswordHash string
+}
+
+// SQLStore implémente store.Store au-dessus d'un *gorm.DB déjà migré.
+type SQLStore struct {
+	db *gorm.DB
+}
+
+// New enveloppe un *gorm.DB dans un SQLStore.
+func New(db *gorm.DB) *SQLStore {
+	return &SQLStore{db: db}
+}
+
+// Migrate crée les tables utilisées par le SQLStore.
+func Migrate(db *gorm.DB) error {
+	return db.AutoMigrate(&workflowRow{}, &runRow{}, &runStepRow{}, &userRow{})
+}
+
+func toWorkflowRow(wf models.Workflow) (workflowRow, error) {
+	stepsJSON, err := json.Marshal(wf.Steps)
+	if err != nil {
+		return workflowRow{}, err
+	}
+	row := workflowRow{ID: wf.ID, Name: wf.Name, StepsJSON: string(stepsJSON), TriggerToken: wf.TriggerToken, OwnerID: wf.OwnerID}
+	if wf.Trigger != nil {
+		configJSON, err := json.Marshal(wf.Trigger.Config)
+		if err != nil {
+			return workflowRow{}, err
+		}
+		row.TriggerType = wf.Trigger.Type
+		row.TriggerConfig = string(configJSON)
+	}
+	return row, nil
+}
+
+func fromWorkflowRow(row workflowRow) (models.Workflow, error) {
+	var steps []engine.ActionStep
+	if row.StepsJSON != "" {
+		if err := json.Unmarshal([]byte(row.StepsJSON), &steps); err != nil {
+			return models.Workflow{}, err
+		}
+	}
+	wf := models.Workflow{ID: row.ID, Name: row.Name, Steps: steps, TriggerToken: row.TriggerToken, OwnerID: row.OwnerID}
+	if row.TriggerType != "" {
+		var config map[string]interface{}
+		if row.TriggerConfig != "" {
+			if err := json.Unmarshal([]byte(row.TriggerConfig), &config); err != nil {
+				return models.Workflow{}, err
+			}
+		}
+		wf.Trigger = &models.Trigger{Type: row.TriggerType, Config: config}
+	}
+	return wf, nil
+}
+
+func (s *SQLStore) CreateWorkflow(wf models.Workflow) (models.Workflow, error) {
+	if wf.ID == "" {
+		wf.ID = engine.NewID()
+	}
+	row, err := toWorkflowRow(wf)
+	if err != nil {
+		return models.Workflow{}, err
+	}
+	if err := s.db.Create(&row).Error; err != nil {
+		return models.Workflow{}, err
+	}
+	return fromWorkflowRow(row)
+}
+
+func (s *SQLStore) GetWorkflow(id string) (models.Workflow, error) {
+	var row workflowRow
+	if err := s.db.First(&row, "id = ?", id).Error; err != nil {
+		return models.Workflow{}, err
+	}
+	return fromWorkflowRow(row)
+}
+
+func (s *SQLStore) ListWorkflows() ([]models.Workflow, error) {
+	var rows []workflowRow
+	if err := s.db.Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return workflowsFromRows(rows)
+}
+
+func (s *SQLStore) ListWorkflowsByOwner(ownerID string) ([]models.Workflow, error) {
+	var rows []workflowRow
+	if err := s.db.Where("owner_id = ?", ownerID).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return workflowsFromRows(rows)
+}
+
+func workflowsFromRows(rows []workflowRow) ([]models.Workflow, error) {
+	workflows := make([]models.Workflow, 0, len(rows))
+	for _, row := range rows {
+		wf, err := fromWorkflowRow(row)
+		if err != nil {
+			return nil, err
+		}
+		workflows = append(workflows, wf)
+	}
+	return workflows, nil
+}
+
+func (s *SQLStore) UpdateWorkflow(wf models.Workflow) (models.Workflow, error) {
+	row, err := toWorkflowRow(wf)
+	if err != nil {
+		return models.Workflow{}, err
+	}
+	if err := s.db.Model(&workflowRow{}).Where("id = ?", wf.ID).Updates(map[string]interface{}{
+		"name":           row.Name,
+		"steps_json":     row.StepsJSON,
+		"trigger_type":   row.TriggerType,
+		"trigger_config": row.TriggerConfig,
+		"trigger_token":  row.TriggerToken,
+		"owner_id":       row.OwnerID,
+	}).Error; err != nil {
+		return models.Workflow{}, err
+	}
+	return wf, nil
+}
+
+func (s *SQLStore) DeleteWorkflow(id string) error {
+	return s.db.Delete(&workflowRow{}, "id = ?", id).Error
+}
+
+func (s *SQLStore) GetWorkflowByTriggerToken(token string) (models.Workflow, error) {
+	var row workflowRow
+	if err := s.db.First(&row, "trigger_token = ?", token).Error; err != nil {
+		return models.Workflow{}, err
+	}
+	return fromWorkflowRow(row)
+}
+
+func (s *SQLStore) CreateRun(run engine.Run) (engine.Run, error) {
+	if run.ID == "" {
+		run.ID = engine.NewID()
+	}
+	row := runRow{ID: run.ID, WorkflowID: run.WorkflowID, Status: run.Status}
+	if err := s.db.Create(&row).Error; err != nil {
+		return engine.Run{}, err
+	}
+	return run, nil
+}
+
+func (s *SQLStore) UpdateRunStatus(runID string, status string) error {
+	return s.db.Model(&runRow{}).Where("id = ?", runID).Update("status", status).Error
+}
+
+func (s *SQLStore) AppendRunStep(runID string, step engine.StepResult) error {
+	row := runStepRow{RunID: runID, Name: step.Name, Status: step.Status, Output: step.Output, Error: step.Error}
+	return s.db.Create(&row).Error
+}
+
+func (s *SQLStore) GetRun(id string) (engine.Run, error) {
+	var row runRow
+	if err := s.db.First(&row, "id = ?", id).Error; err != nil {
+		return engine.Run{}, err
+	}
+	var stepRows []runStepRow
+	if err := s.db.Where("run_id = ?", id).Find(&stepRows).Error; err != nil {
+		return engine.Run{}, err
+	}
+	steps := make([]engine.StepResult, 0, len(stepRows))
+	for _, sr := range stepRows {
+		steps = append(steps, engine.StepResult{Name: sr.Name, Status: sr.Status, Output: sr.Output, Error: sr.Error})
+	}
+	return engine.Run{ID: row.ID, WorkflowID: row.WorkflowID, Status: row.Status, Steps: steps}, nil
+}
+
+func toUserModel(row userRow) models.User {
+	return models.User{ID: strconv.FormatUint(uint64(row.ID), 10), Name: row.Name, Email: row.Email, PasswordHash: row.PasswordHash}
+}
+
+func (s *SQLStore) CreateUser(user models.User) (models.User, error) {
+	row := userRow{Name: user.Name, Email: user.Email, PasswordHash: user.PasswordHash}
+	if err := s.db.Create(&row).Error; err != nil {
+		return models.User{}, err
+	}
+	return toUserModel(row), nil
+}
+
+func (s *SQLStore) GetUser(id string) (models.User, error) {
+	var row userRow
+	if err := s.db.First(&row, "id = ?", id).Error; err != nil {
+		return models.User{}, err
+	}
+	return toUserModel(row), nil
+}
+
+func (s *SQLStore) GetUserByEmail(email string) (models.User, error) {
+	var row userRow
+	if err := s.db.First(&row, "email = ?", email).Error; err != nil {
+		return models.User{}, err
+	}
+	return toUserModel(row), nil
+}
+
+func (s *SQLStore) ListUsers(opts store.ListUsersOptions) ([]models.User, int64, error) {
+	query := s.db.Model(&userRow{})
+	if opts.NameLike != "" {
+		query = query.Where("name LIKE ?", "%"+opts.NameLike+"%")
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	sortColumn := opts.SortColumn
+	if sortColumn == "" {
+		sortColumn = "id"
+	}
+	order := sortColumn + " ASC"
+	if strings.EqualFold(opts.SortOrder, "desc") {
+		order = sortColumn + " DESC"
+	}
+
+	var rows []userRow
+	if err := query.Order(order).Limit(opts.Limit).Offset(opts.Offset).Find(&rows).Error; err != nil {
+		return nil, 0, err
+	}
+	users := make([]models.User, 0, len(rows))
+	for _, row := range rows {
+		users = append(users, toUserModel(row))
+	}
+	return users, total, nil
+}
+
+func (s *SQLStore) UpdateUser(user models.User) (models.User, error) {
+	if err := s.db.Model(&userRow{}).Where("id = ?", user.ID).Update("name", user.Name).Error; err != nil {
+		return models.User{}, err
+	}
+	return user, nil
+}
+
+func (s *SQLStore) DeleteUser(id string) error {
+	return s.db.Delete(&userRow{}, "id = ?", id).Error
+}
+
+// AddTestUsers insère n utilisateurs de test ("User 1", "User 2", ...),
+// utilisé par le endpoint de charge /users/sql/test-add.
+func (s *SQLStore) AddTestUsers(n int) error {
+	rows := make([]userRow, 0, n)
+	for i := 1; i <= n; i++ {
+		rows = append(rows, userRow{Name: "User " + strconv.Itoa(i), Email: "test-" + engine.NewID() + "@example.com"})
+	}
+	return s.db.Create(&rows).Error
+}
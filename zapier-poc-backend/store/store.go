@@ -0,0 +1,44 @@
+// Package store définit l'interface de persistance commune aux backends
+// SQL et Mongo, pour que les handlers n'aient jamais à connaître le
+// backend effectivement utilisé.
+package store
+
+import (
+	"zapierpoc/engine"
+	"zapierpoc/models"
+)
+
+// ListUsersOptions contrôle la pagination, le tri et le filtrage de
+// ListUsers. SortColumn doit déjà avoir été validé par l'appelant contre
+// une liste blanche ("id", "name").
+type ListUsersOptions struct {
+	Limit      int
+	Offset     int
+	SortColumn string
+	SortOrder  string // "asc" ou "desc"
+	NameLike   string
+}
+
+// Store persiste les workflows, leurs runs et les utilisateurs, quel que
+// soit le backend (SQL via gorm ou Mongo) qui l'implémente.
+type Store interface {
+	CreateWorkflow(wf models.Workflow) (models.Workflow, error)
+	GetWorkflow(id string) (models.Workflow, error)
+	GetWorkflowByTriggerToken(token string) (models.Workflow, error)
+	ListWorkflows() ([]models.Workflow, error)
+	ListWorkflowsByOwner(ownerID string) ([]models.Workflow, error)
+	UpdateWorkflow(wf models.Workflow) (models.Workflow, error)
+	DeleteWorkflow(id string) error
+
+	CreateRun(run engine.Run) (engine.Run, error)
+	UpdateRunStatus(runID string, status string) error
+	AppendRunStep(runID string, step engine.StepResult) error
+	GetRun(id string) (engine.Run, error)
+
+	CreateUser(user models.User) (models.User, error)
+	GetUser(id string) (models.User, error)
+	GetUserByEmail(email string) (models.User, error)
+	ListUsers(opts ListUsersOptions) ([]models.User, int64, error)
+	UpdateUser(user models.User) (models.User, error)
+	DeleteUser(id string) error
+}
@@ -0,0 +1,101 @@
+package trigger
+
+import (
+	"log"
+	"sync"
+
+	"zapierpoc/models"
+	"zapierpoc/store"
+
+	"github.com/gin-gonic/gin"
+	"github.com/robfig/cron/v3"
+)
+
+// CronTrigger planifie l'exécution des workflows dont le Trigger est de
+// type "cron", selon l'expression cron fournie dans Trigger.Config["schedule"].
+// Au-delà du chargement initial fait par Register, Schedule et Unschedule
+// permettent à WorkflowHandler de tenir le scheduler à jour quand un
+// workflow est créé, modifié ou supprimé après le démarrage.
+type CronTrigger struct {
+	store    store.Store
+	cron     *cron.Cron
+	enqueuer RunEnqueuer
+
+	mu      sync.Mutex
+	entries map[string]cron.EntryID
+}
+
+// NewCronTrigger construit un CronTrigger pour le store donné.
+func NewCronTrigger(s store.Store) *CronTrigger {
+	return &CronTrigger{store: s, cron: cron.New(), entries: map[string]cron.EntryID{}}
+}
+
+// Register charge les workflows à déclencher par cron et programme leur
+// exécution, puis démarre le scheduler.
+func (t *CronTrigger) Register(r *gin.Engine, enqueuer RunEnqueuer) error {
+	t.enqueuer = enqueuer
+
+	workflows, err := t.store.ListWorkflows()
+	if err != nil {
+		return err
+	}
+	for _, wf := range workflows {
+		if err := t.Schedule(wf); err != nil {
+			log.Printf("cron trigger: invalid schedule for workflow %s: %v", wf.ID, err)
+		}
+	}
+
+	t.cron.Start()
+	return nil
+}
+
+// Schedule (re)programme le trigger cron d'un workflow : toute
+// planification précédente pour ce workflow est retirée, puis une
+// nouvelle est ajoutée si le workflow a un Trigger de type "cron" avec un
+// schedule valide. Elle doit être appelée chaque fois qu'un workflow est
+// créé ou modifié.
+func (t *CronTrigger) Schedule(wf models.Workflow) error {
+	t.Unschedule(wf.ID)
+
+	if wf.Trigger == nil || wf.Trigger.Type != "cron" {
+		return nil
+	}
+	schedule, _ := wf.Trigger.Config["schedule"].(string)
+	if schedule == "" {
+		return nil
+	}
+
+	entryID, err := t.cron.AddFunc(schedule, func() {
+		if _, err := t.enqueuer.SubmitWithContext(wf.ID, wf.Steps, nil); err != nil {
+			log.Printf("cron trigger: workflow %s: %v", wf.ID, err)
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	t.entries[wf.ID] = entryID
+	t.mu.Unlock()
+	return nil
+}
+
+// Unschedule retire toute planification cron existante pour ce workflow.
+// Elle ne fait rien si le workflow n'en a pas.
+func (t *CronTrigger) Unschedule(workflowID string) {
+	t.mu.Lock()
+	entryID, ok := t.entries[workflowID]
+	if ok {
+		delete(t.entries, workflowID)
+	}
+	t.mu.Unlock()
+
+	if ok {
+		t.cron.Remove(entryID)
+	}
+}
+
+// Stop arrête le scheduler cron.
+func (t *CronTrigger) Stop() {
+	t.cron.Stop()
+}
@@ -0,0 +1,23 @@
+// Package trigger fournit les déclencheurs qui démarrent un workflow sans
+// passer par POST /workflows/:id/execute : webhook entrant, planification
+// cron, etc.
+package trigger
+
+import (
+	"zapierpoc/engine"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RunEnqueuer est la vue qu'un Trigger a sur l'executor : juste de quoi
+// démarrer un run à partir d'un contexte initial.
+type RunEnqueuer interface {
+	SubmitWithContext(workflowID string, steps []engine.ActionStep, initialContext map[string]interface{}) (string, error)
+}
+
+// Trigger enregistre ses routes ou ses horaires et peut être arrêté
+// proprement.
+type Trigger interface {
+	Register(r *gin.Engine, enqueuer RunEnqueuer) error
+	Stop()
+}
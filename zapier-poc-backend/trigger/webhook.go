@@ -0,0 +1,58 @@
+package trigger
+
+import (
+	"errors"
+	"net/http"
+
+	"zapierpoc/engine"
+	"zapierpoc/store"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WebhookTrigger démarre le workflow lié à un token quand
+// POST /triggers/webhook/:token est appelé.
+type WebhookTrigger struct {
+	store store.Store
+}
+
+// NewWebhookTrigger construit un WebhookTrigger pour le store donné.
+func NewWebhookTrigger(s store.Store) *WebhookTrigger {
+	return &WebhookTrigger{store: s}
+}
+
+// Register enregistre la route POST /triggers/webhook/:token.
+func (t *WebhookTrigger) Register(r *gin.Engine, enqueuer RunEnqueuer) error {
+	r.POST("/triggers/webhook/:token", func(c *gin.Context) {
+		wf, err := t.store.GetWorkflowByTriggerToken(c.Param("token"))
+		if err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "unknown trigger token"})
+			return
+		}
+
+		var body interface{}
+		_ = c.ShouldBindJSON(&body)
+
+		headers := map[string]interface{}{}
+		for key, values := range c.Request.Header {
+			if len(values) > 0 {
+				headers[key] = values[0]
+			}
+		}
+
+		runID, err := enqueuer.SubmitWithContext(wf.ID, wf.Steps, map[string]interface{}{"body": body, "headers": headers})
+		if err != nil {
+			if errors.Is(err, engine.ErrQueueFull) {
+				c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusAccepted, gin.H{"run_id": runID})
+	})
+	return nil
+}
+
+// Stop n'a rien à libérer : la route vit avec le serveur HTTP.
+func (t *WebhookTrigger) Stop() {}